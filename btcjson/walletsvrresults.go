@@ -161,18 +161,22 @@ type ListSinceBlockResult struct {
 }
 
 // ListUnspentResult models a successful response from the listunspent request.
+//
+// Address is the first address paid by the output.  Addresses lists every
+// address extracted from the script.
 type ListUnspentResult struct {
-	TxID          string  `json:"txid"`
-	Vout          uint32  `json:"vout"`
-	Address       string  `json:"address"`
-	Account       string  `json:"account"`
-	ScriptPubKey  string  `json:"scriptPubKey"`
-	RedeemScript  string  `json:"redeemScript,omitempty"`
-	Amount        float64 `json:"amount"`
-	Confirmations int64   `json:"confirmations"`
-	Height        int64   `json:"height"`
-	BlockHash     string  `json:"blockHash"`
-	Spendable     bool    `json:"spendable"`
+	TxID          string   `json:"txid"`
+	Vout          uint32   `json:"vout"`
+	Address       string   `json:"address"`
+	Addresses     []string `json:"addresses,omitempty"`
+	Account       string   `json:"account"`
+	ScriptPubKey  string   `json:"scriptPubKey"`
+	RedeemScript  string   `json:"redeemScript,omitempty"`
+	Amount        float64  `json:"amount"`
+	Confirmations int64    `json:"confirmations"`
+	Height        int64    `json:"height"`
+	BlockHash     string   `json:"blockHash"`
+	Spendable     bool     `json:"spendable"`
 }
 
 // SignRawTransactionError models the data that contains script verification
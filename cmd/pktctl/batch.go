@@ -0,0 +1,137 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/pkt-cash/PKT-FullNode/btcjson"
+	"github.com/pkt-cash/PKT-FullNode/btcutil/er"
+)
+
+// batchResult is the response which is written for each line of a batch.
+// The id is the line number of the command which produced it so that results
+// can be matched to commands even when some lines are skipped.
+type batchResult struct {
+	Result json.RawMessage `json:"result"`
+	Error  *btcjson.RPCErr `json:"error"`
+	ID     int             `json:"id"`
+}
+
+// runBatch reads one command per line from r, sends each of them to the server
+// as soon as it is read and writes its JSON response as one line to w.  Each
+// line is a method followed by its arguments, separated by whitespace, so
+// arguments containing whitespace are not supported.  Blank lines and lines
+// starting with '#' are ignored.
+//
+// Every command is a request of its own, with its own timeout, sent using a
+// single HTTP client which keeps the connection open between requests when the
+// server allows it.  A command which cannot be created, fails to be sent or is
+// rejected by the server produces an error object for that line only and the
+// rest of the batch is unaffected.
+func runBatch(r io.Reader, w io.Writer, cfg *config) er.R {
+	httpClient, err := newHTTPClient(cfg)
+	if err != nil {
+		return err
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 32*1024*1024)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		res := runBatchLine(httpClient, lineNum, line, cfg)
+		out, errr := json.Marshal(&res)
+		if errr != nil {
+			return er.E(errr)
+		}
+		out = append(out, '\n')
+		if _, errr := w.Write(out); errr != nil {
+			return er.E(errr)
+		}
+	}
+	if errr := scanner.Err(); errr != nil {
+		return er.Errorf("Failed to read commands from stdin: %v", errr)
+	}
+	return nil
+}
+
+// runBatchLine parses a single batch line into a registered command, sends it
+// to the server using the line number as the id and returns the result.
+func runBatchLine(httpClient *http.Client, lineNum int, line string,
+	cfg *config) batchResult {
+
+	res := batchResult{ID: lineNum}
+
+	fields := strings.Fields(line)
+	method := fields[0]
+	if err := checkMethod(method, cfg); err != nil {
+		code := btcjson.ErrRPCInvalidRequest
+		if _, err := btcjson.MethodUsageFlags(method); err != nil {
+			code = btcjson.ErrRPCMethodNotFound
+		}
+		res.Error = batchError(err, code)
+		return res
+	}
+
+	params := make([]interface{}, 0, len(fields[1:]))
+	for _, arg := range fields[1:] {
+		params = append(params, arg)
+	}
+	cmd, err := btcjson.NewCmd(method, params...)
+	if err != nil {
+		res.Error = batchError(err, btcjson.ErrRPCInvalidParams)
+		return res
+	}
+	marshalledJSON, err := btcjson.MarshalCmd(lineNum, cmd)
+	if err != nil {
+		res.Error = batchError(err, btcjson.ErrRPCInvalidParams)
+		return res
+	}
+
+	resp, err := postRequest(httpClient, marshalledJSON, cfg, true)
+	if err != nil {
+		res.Error = batchError(err, btcjson.ErrRPCMisc)
+		return res
+	}
+
+	// The server echoes the id of the request, which is the line number.
+	if id, ok := responseID(resp); !ok || id != lineNum {
+		res.Error = batchError(er.New("Response id does not match the "+
+			"request"), btcjson.ErrRPCMisc)
+		return res
+	}
+	res.Result = json.RawMessage(resp.Result)
+	res.Error = resp.Error
+	return res
+}
+
+// responseID returns the numeric id of the passed response.
+func responseID(resp *btcjson.Response) (int, bool) {
+	if resp.ID == nil {
+		return 0, false
+	}
+	id, ok := (*resp.ID).(float64)
+	return int(id), ok
+}
+
+// batchError converts an error which happened locally into the error object
+// written for a batch line.  The JSON-RPC code carried by the error is used
+// when there is one, otherwise the passed code is used, which should be the
+// code the server would reply with for the same problem.
+func batchError(err er.R, code *er.ErrorCode) *btcjson.RPCErr {
+	if c := btcjson.Err.Decode(err); c != nil && c.Number != 0 {
+		code = c
+	}
+	return &btcjson.RPCErr{
+		Code:    code.Number,
+		Message: err.Message(),
+	}
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pkt-cash/PKT-FullNode/btcjson"
+)
+
+// testRequest is the part of a JSON-RPC request the test server looks at.
+type testRequest struct {
+	Method string      `json:"method"`
+	ID     interface{} `json:"id"`
+}
+
+// TestRunBatch ensures every line of a batch gets its own result, numbered by
+// its line, and that a line which fails does not affect the others.
+func TestRunBatch(t *testing.T) {
+	var mtx sync.Mutex
+	conns := make(map[string]struct{})
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			mtx.Lock()
+			conns[r.RemoteAddr] = struct{}{}
+			mtx.Unlock()
+
+			var req testRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			reply := map[string]interface{}{
+				"result": nil,
+				"error":  nil,
+				"id":     req.ID,
+			}
+			switch req.Method {
+			case "getblockcount":
+				reply["result"] = 42
+			case "getbestblockhash":
+				// Answer with an id which does not match the line.
+				reply["id"] = 1000
+			default:
+				reply["error"] = map[string]interface{}{
+					"code":    btcjson.ErrRPCInvalidParameter.Number,
+					"message": "server error",
+				}
+			}
+			json.NewEncoder(w).Encode(reply)
+		}))
+	defer server.Close()
+
+	tests := []struct {
+		name   string
+		line   string
+		skip   bool
+		code   int
+		result string
+	}{
+		{name: "result", line: "getblockcount", result: "42"},
+		{name: "blank line", line: "   ", skip: true},
+		{name: "comment", line: "# getblockcount", skip: true},
+		{name: "unknown method", line: "nosuchmethod",
+			code: btcjson.ErrRPCMethodNotFound.Number},
+		{name: "wallet method", line: "getbalance",
+			code: btcjson.ErrRPCInvalidRequest.Number},
+		{name: "bad params", line: "getblock",
+			code: btcjson.ErrRPCInvalidParams.Number},
+		{name: "id mismatch", line: "getbestblockhash",
+			code: btcjson.ErrRPCMisc.Number},
+		{name: "server error", line: "getblockhash 1",
+			code: btcjson.ErrRPCInvalidParameter.Number},
+		{name: "after failures", line: "getblockcount", result: "42"},
+	}
+
+	lines := make([]string, 0, len(tests))
+	for _, test := range tests {
+		lines = append(lines, test.line)
+	}
+	cfg := &config{
+		RPCServer: strings.TrimPrefix(server.URL, "http://"),
+		Timeout:   time.Minute,
+	}
+	var out bytes.Buffer
+	err := runBatch(strings.NewReader(strings.Join(lines, "\n")), &out, cfg)
+	if err != nil {
+		t.Fatalf("runBatch failed: %v", err)
+	}
+
+	dec := json.NewDecoder(&out)
+	for i, test := range tests {
+		if test.skip {
+			continue
+		}
+		var res batchResult
+		if err := dec.Decode(&res); err != nil {
+			t.Fatalf("%s: failed to decode result: %v", test.name, err)
+		}
+		if res.ID != i+1 {
+			t.Errorf("%s: got id %d, want %d", test.name, res.ID, i+1)
+		}
+		if test.code != 0 {
+			if res.Error == nil {
+				t.Errorf("%s: no error, want code %d", test.name,
+					test.code)
+			} else if res.Error.Code != test.code {
+				t.Errorf("%s: got error code %d (%s), want %d",
+					test.name, res.Error.Code, res.Error.Message,
+					test.code)
+			}
+			continue
+		}
+		if res.Error != nil {
+			t.Errorf("%s: unexpected error: %s", test.name,
+				res.Error.Message)
+			continue
+		}
+		if string(res.Result) != test.result {
+			t.Errorf("%s: got result %s, want %s", test.name,
+				res.Result, test.result)
+		}
+	}
+	if dec.More() {
+		t.Errorf("unexpected extra results")
+	}
+
+	// All the requests which reached the server share one connection.
+	if len(conns) != 1 {
+		t.Errorf("requests used %d connections, want 1", len(conns))
+	}
+}
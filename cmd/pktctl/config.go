@@ -10,6 +10,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/pkt-cash/PKT-FullNode/btcutil/er"
 	"github.com/pkt-cash/PKT-FullNode/pktconfig"
@@ -35,6 +36,7 @@ var (
 	defaultRPCServer      = "localhost"
 	defaultRPCCertFile    = filepath.Join(pktdHomeDir, "rpc.cert")
 	defaultWalletCertFile = filepath.Join(pktwalletHomeDir, "rpc.cert")
+	defaultTimeout        = 10 * time.Minute
 )
 
 // listCommands categorizes and lists all of the usable commands along with
@@ -94,22 +96,23 @@ func listCommands() {
 //
 // See loadConfig for details on the configuration load process.
 type config struct {
-	ShowVersion   bool   `short:"V" long:"version" description:"Display version information and exit"`
-	ListCommands  bool   `short:"l" long:"listcommands" description:"List all of the supported commands and exit"`
-	ConfigFile    string `short:"C" long:"configfile" description:"Path to configuration file"`
-	RPCUser       string `short:"u" long:"rpcuser" description:"RPC username"`
-	RPCPassword   string `short:"P" long:"rpcpass" default-mask:"-" description:"RPC password"`
-	RPCServer     string `short:"s" long:"rpcserver" description:"RPC server to connect to"`
-	RPCCert       string `short:"c" long:"rpccert" description:"RPC server certificate chain for validation"`
-	NoTLS         bool   `long:"notls" description:"Disable TLS"`
-	TLS           bool   `long:"tls" description:"Enable TLS - default false except for wallet"`
-	TestNet3      bool   `long:"testnet" description:"Connect to testnet"`
-	PktTest       bool   `long:"pkttest" description:"Use the pkt.cash test network"`
-	BtcMainNet    bool   `long:"btc" description:"Use the bitcoin main network"`
-	PktMainNet    bool   `long:"pkt" description:"Use the pkt.cash main network"`
-	SimNet        bool   `long:"simnet" description:"Connect to the simulation test network"`
-	TLSSkipVerify bool   `long:"skipverify" description:"Do not verify tls certificates (not recommended!)"`
-	Wallet        bool   `long:"wallet" description:"Connect to wallet"`
+	ShowVersion   bool          `short:"V" long:"version" description:"Display version information and exit"`
+	ListCommands  bool          `short:"l" long:"listcommands" description:"List all of the supported commands and exit"`
+	ConfigFile    string        `short:"C" long:"configfile" description:"Path to configuration file"`
+	RPCUser       string        `short:"u" long:"rpcuser" description:"RPC username"`
+	RPCPassword   string        `short:"P" long:"rpcpass" default-mask:"-" description:"RPC password"`
+	RPCServer     string        `short:"s" long:"rpcserver" description:"RPC server to connect to"`
+	RPCCert       string        `short:"c" long:"rpccert" description:"RPC server certificate chain for validation"`
+	NoTLS         bool          `long:"notls" description:"Disable TLS"`
+	TLS           bool          `long:"tls" description:"Enable TLS - default false except for wallet"`
+	TestNet3      bool          `long:"testnet" description:"Connect to testnet"`
+	PktTest       bool          `long:"pkttest" description:"Use the pkt.cash test network"`
+	BtcMainNet    bool          `long:"btc" description:"Use the bitcoin main network"`
+	PktMainNet    bool          `long:"pkt" description:"Use the pkt.cash main network"`
+	SimNet        bool          `long:"simnet" description:"Connect to the simulation test network"`
+	TLSSkipVerify bool          `long:"skipverify" description:"Do not verify tls certificates (not recommended!)"`
+	Wallet        bool          `long:"wallet" description:"Connect to wallet"`
+	Timeout       time.Duration `long:"timeout" description:"Time to wait for the RPC server to reply, 0 to wait forever"`
 }
 
 // normalizeAddress returns addr with the passed default port appended if
@@ -193,6 +196,7 @@ func loadConfig() (*config, []string, er.R) {
 		ConfigFile: defaultConfigFile,
 		RPCServer:  defaultRPCServer,
 		RPCCert:    defaultRPCCertFile,
+		Timeout:    defaultTimeout,
 	}
 
 	// Pre-parse the command line options to see if an alternative config
@@ -210,6 +214,10 @@ func loadConfig() (*config, []string, er.R) {
 				"indicates that a parameter should be read "+
 				"from the\nnext unread line from standard "+
 				"input.")
+			fmt.Fprintln(os.Stderr, "When the command itself is "+
+				"`-` or `batch`, one command is read per\nline "+
+				"of standard input and one JSON result is "+
+				"written per line.")
 			return nil, nil, er.E(err)
 		}
 	}
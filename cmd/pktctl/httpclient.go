@@ -44,6 +44,7 @@ func newHTTPClient(cfg *config) (*http.Client, er.R) {
 			Dial:            dial,
 			TLSClientConfig: tlsConfig,
 		},
+		Timeout: cfg.Timeout,
 	}
 	return &client, nil
 }
@@ -53,6 +54,22 @@ func newHTTPClient(cfg *config) (*http.Client, er.R) {
 // unmarshal the response as a JSON-RPC response and returns either the result
 // field or the error field depending on whether or not there is an error.
 func sendPostRequest(marshalledJSON []byte, cfg *config) (*btcjson.Response, er.R) {
+	// Create the new HTTP client that is configured according to the user-
+	// specified options and submit the request.
+	httpClient, err := newHTTPClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return postRequest(httpClient, marshalledJSON, cfg, false)
+}
+
+// postRequest sends the marshalled JSON-RPC command with the passed HTTP
+// client and unmarshals the response like sendPostRequest.  When keepAlive is
+// set the connection is left open so the client can reuse it for the next
+// request.
+func postRequest(httpClient *http.Client, marshalledJSON []byte, cfg *config,
+	keepAlive bool) (*btcjson.Response, er.R) {
+
 	// Generate a request to the configured RPC server.
 	protocol := "http"
 	if cfg.TLS {
@@ -64,34 +81,32 @@ func sendPostRequest(marshalledJSON []byte, cfg *config) (*btcjson.Response, er.
 	if errr != nil {
 		return nil, er.E(errr)
 	}
-	httpRequest.Close = true
+	httpRequest.Close = !keepAlive
 	httpRequest.Header.Set("Content-Type", "application/json")
 	httpRequest.Header.Set("X-Pkt-RPC-Version", fmt.Sprintf("%d", version.AppMajorVersion()))
 
 	// Configure basic access authorization.
 	httpRequest.SetBasicAuth(cfg.RPCUser, cfg.RPCPassword)
 
-	// Create the new HTTP client that is configured according to the user-
-	// specified options and submit the request.
-	httpClient, err := newHTTPClient(cfg)
-	if err != nil {
-		return nil, err
-	}
 	httpResponse, errr := httpClient.Do(httpRequest)
 	if errr != nil {
+		if isTimeout(errr) {
+			return nil, timeoutError(cfg)
+		}
 		return nil, er.E(errr)
 	}
 
 	// Read the raw bytes and close the response.
 	respBytes, errr := ioutil.ReadAll(httpResponse.Body)
 	if errr != nil {
-		err = er.Errorf("error reading json reply: %v", errr)
-		return nil, err
+		if isTimeout(errr) {
+			return nil, timeoutError(cfg)
+		}
+		return nil, er.Errorf("error reading json reply: %v", errr)
 	}
 	errrr := httpResponse.Body.Close()
 	if errrr != nil {
-		err = er.Errorf("error closing connection: %v", errrr)
-		return nil, err
+		return nil, er.Errorf("error closing connection: %v", errrr)
 	}
 
 	// Handle unsuccessful HTTP responses
@@ -119,3 +134,25 @@ func sendPostRequest(marshalledJSON []byte, cfg *config) (*btcjson.Response, er.
 
 	return &resp, nil
 }
+
+// isTimeout returns whether the passed error is the result of the request
+// exceeding the client deadline.
+func isTimeout(err error) bool {
+	netErr, ok := err.(net.Error)
+	return ok && netErr.Timeout()
+}
+
+// clientErr is the type of the errors raised by pktctl itself.
+var clientErr er.ErrorType = er.NewErrorType("pktctl.Err")
+
+// errTimeout is returned when the server did not reply within the configured
+// timeout.
+var errTimeout = clientErr.Code("ErrTimeout")
+
+// timeoutError returns the error shown when the server did not reply within
+// the configured timeout.
+func timeoutError(cfg *config) er.R {
+	return errTimeout.New(fmt.Sprintf("no reply from %s within %v, the "+
+		"request may still be running on the server (use --timeout to "+
+		"wait longer)", cfg.RPCServer, cfg.Timeout), nil)
+}
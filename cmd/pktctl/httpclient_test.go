@@ -0,0 +1,89 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestSendPostRequestTimeout ensures a server which does not reply within the
+// configured timeout results in errTimeout, whether it stalls before sending
+// the headers or while sending the body, and that a zero timeout waits for
+// the reply.
+func TestSendPostRequestTimeout(t *testing.T) {
+	const delay = 300 * time.Millisecond
+	const reply = `{"result":42,"error":null,"id":1}`
+
+	tests := []struct {
+		name     string
+		timeout  time.Duration
+		handler  http.HandlerFunc
+		timedOut bool
+	}{
+		{
+			name:    "stall before headers",
+			timeout: 50 * time.Millisecond,
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				sleepOrDone(r, delay)
+				w.Write([]byte(reply))
+			},
+			timedOut: true,
+		},
+		{
+			name:    "stall during body",
+			timeout: 50 * time.Millisecond,
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(reply[:10]))
+				w.(http.Flusher).Flush()
+				sleepOrDone(r, delay)
+				w.Write([]byte(reply[10:]))
+			},
+			timedOut: true,
+		},
+		{
+			name:    "no timeout",
+			timeout: 0,
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				sleepOrDone(r, delay)
+				w.Write([]byte(reply))
+			},
+		},
+	}
+
+	body := []byte(`{"jsonrpc":"1.0","method":"getblockcount","params":[],"id":1}`)
+	for _, test := range tests {
+		server := httptest.NewServer(test.handler)
+		cfg := &config{
+			RPCServer: strings.TrimPrefix(server.URL, "http://"),
+			Timeout:   test.timeout,
+		}
+		resp, err := sendPostRequest(body, cfg)
+		server.Close()
+
+		if test.timedOut {
+			if !errTimeout.Is(err) {
+				t.Errorf("%s: got error %v, want %v", test.name, err,
+					errTimeout)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+		if string(resp.Result) != "42" {
+			t.Errorf("%s: got result %s, want 42", test.name,
+				resp.Result)
+		}
+	}
+}
+
+// sleepOrDone waits for d, or until the client gives up on the request.
+func sleepOrDone(r *http.Request, d time.Duration) {
+	select {
+	case <-time.After(d):
+	case <-r.Context().Done():
+	}
+}
@@ -13,6 +13,7 @@ import (
 	jsoniter "github.com/json-iterator/go"
 
 	"github.com/pkt-cash/PKT-FullNode/btcjson"
+	"github.com/pkt-cash/PKT-FullNode/btcutil/er"
 	"github.com/pkt-cash/PKT-FullNode/pktconfig/version"
 )
 
@@ -49,6 +50,27 @@ func usage(errorMessage string) {
 	fmt.Fprintln(os.Stderr, listCmdMessage)
 }
 
+// checkMethod ensures the specified method identifies a valid registered
+// command which can be used by this utility against the configured server.
+func checkMethod(method string, cfg *config) er.R {
+	usageFlags, err := btcjson.MethodUsageFlags(method)
+	if err != nil {
+		return er.Errorf("Unrecognized command '%s'", method)
+	}
+	if usageFlags&unusableFlags != 0 {
+		return er.Errorf("The '%s' command can only be used via "+
+			"websockets", method)
+	}
+
+	// Wallet-only commands are not served by pktd, so refuse to send them
+	// there rather than letting the user guess why the request failed.
+	if usageFlags&btcjson.UFWalletOnly != 0 && !cfg.Wallet {
+		return er.Errorf("The '%s' command is a wallet command, "+
+			"specify --wallet to send it to pktwallet", method)
+	}
+	return nil
+}
+
 func main() {
 	version.SetUserAgentName("pktctl")
 	cfg, args, err := loadConfig()
@@ -61,18 +83,25 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Commands may also be read one per line from stdin, in which case
+	// every line gets its own result or error on stdout.
+	if args[0] == "-" || args[0] == "batch" {
+		if len(args) > 1 {
+			usage("The batch command takes no arguments")
+			os.Exit(1)
+		}
+		if err := runBatch(os.Stdin, os.Stdout, cfg); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	// Ensure the specified method identifies a valid registered command and
 	// is one of the usable types.
 	method := args[0]
-	usageFlags, err := btcjson.MethodUsageFlags(method)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Unrecognized command '%s'\n", method)
-		fmt.Fprintln(os.Stderr, listCmdMessage)
-		os.Exit(1)
-	}
-	if usageFlags&unusableFlags != 0 {
-		fmt.Fprintf(os.Stderr, "The '%s' command can only be used via "+
-			"websockets\n", method)
+	if err := checkMethod(method, cfg); err != nil {
+		fmt.Fprintln(os.Stderr, err.Message())
 		fmt.Fprintln(os.Stderr, listCmdMessage)
 		os.Exit(1)
 	}
@@ -141,7 +170,11 @@ func main() {
 	// Send the JSON-RPC request to the server using the user-specified
 	// connection configuration.
 	result, err := sendPostRequest(marshalledJSON, cfg)
-	if err != nil {
+	if errTimeout.Is(err) {
+		// The stack of a timeout is of no use to the user.
+		fmt.Fprintln(os.Stderr, err.Message())
+		os.Exit(1)
+	} else if err != nil {
 		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
 	}
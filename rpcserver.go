@@ -4189,6 +4189,18 @@ func createResponse(id, result interface{}, jsonErr er.R) (*btcjson.Response, er
 	return btcjson.NewResponse(id, marshalledResult, jsonErr)
 }
 
+// failedResponse creates an error response for a request whose reply could not
+// be created.  The ID of the request is used when possible, but the failure may
+// be caused by the ID itself being of an invalid type, in which case a null ID
+// is used instead.
+func failedResponse(id interface{}, err er.R) (*btcjson.Response, er.R) {
+	jsonErr := internalRPCError(err, "Failed to create response")
+	if !btcjson.IsValidIDType(id) {
+		id = nil
+	}
+	return createResponse(id, nil, jsonErr)
+}
+
 func (s *rpcServer) jsonRPCReq(
 	request *btcjson.Request,
 	closeChan chan struct{},
@@ -4305,6 +4317,12 @@ func (s *rpcServer) jsonRPCRead(w http.ResponseWriter, r *http.Request, isAdmin
 				"Failed to parse requests",
 				er.E(errr),
 			)
+		} else if len(requests) == 0 {
+			jsonErr = btcjson.NewRPCError(
+				btcjson.ErrRPCInvalidRequest,
+				"Empty batch request",
+				nil,
+			)
 		}
 	} else if errr := jsoniter.Unmarshal(body, &req0); errr != nil {
 		jsonErr = btcjson.NewRPCError(
@@ -4319,17 +4337,30 @@ func (s *rpcServer) jsonRPCRead(w http.ResponseWriter, r *http.Request, isAdmin
 	responses := make([]*btcjson.Response, 0, len(requests))
 	if jsonErr == nil {
 		for _, req := range requests {
-			res, jsonErr := s.jsonRPCReq(&req, closeChan, isAdmin)
-			if jsonErr != nil {
-				break
+			res, err := s.jsonRPCReq(&req, closeChan, isAdmin)
+			if err != nil {
+				// Reply to this request alone with the error so the
+				// rest of a batch still gets its own responses.
+				res, err = failedResponse(req.ID, err)
+				if err != nil {
+					log.Error(err)
+					return
+				}
 			}
 			responses = append(responses, res)
 		}
 	}
 
+	// A request which failed to parse has no usable ID, so reply with a
+	// null ID as JSON-RPC requires rather than indexing into nothing.
+	var id interface{}
+	if len(requests) > 0 {
+		id = requests[0].ID
+	}
+
 	var msg []byte
 	if jsonErr != nil {
-		resp, err := createResponse(requests[0].ID, nil, jsonErr)
+		resp, err := createResponse(id, nil, jsonErr)
 		if err != nil {
 			log.Error(err)
 			return
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/pkt-cash/PKT-FullNode/btcjson"
+)
+
+// testResponse is a JSON-RPC response decoded without assuming whether the
+// server replied with a single object or an array.
+type testResponse struct {
+	Error *btcjson.RPCErr `json:"error"`
+	ID    interface{}     `json:"id"`
+}
+
+// TestJSONRPCReadMalformed ensures requests which cannot be handled are
+// answered with JSON errors rather than a panic or a dropped connection.
+func TestJSONRPCReadMalformed(t *testing.T) {
+	s := &rpcServer{statusLines: make(map[int]string)}
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			s.jsonRPCRead(w, r, true)
+		}))
+	defer server.Close()
+
+	tests := []struct {
+		name  string
+		body  string
+		codes []int
+		ids   []interface{}
+		array bool
+	}{
+		{
+			name:  "empty body",
+			body:  "",
+			codes: []int{btcjson.ErrRPCParse.Number},
+			ids:   []interface{}{nil},
+		},
+		{
+			name:  "malformed object",
+			body:  `{"method":`,
+			codes: []int{btcjson.ErrRPCParse.Number},
+			ids:   []interface{}{nil},
+		},
+		{
+			name:  "malformed array",
+			body:  `[{"method":`,
+			codes: []int{btcjson.ErrRPCParse.Number},
+			ids:   []interface{}{nil},
+		},
+		{
+			name:  "empty batch",
+			body:  `[]`,
+			codes: []int{btcjson.ErrRPCInvalidRequest.Number},
+			ids:   []interface{}{nil},
+		},
+		{
+			// The first request has an id of an invalid type, so
+			// no reply can be created for it, which must not
+			// prevent the second one from being answered.
+			name: "batch with unanswerable request",
+			body: `[{"jsonrpc":"1.0","method":"nosuchmethod","params":[],"id":{}},` +
+				`{"jsonrpc":"1.0","method":"nosuchmethod","params":[],"id":2}]`,
+			codes: []int{btcjson.ErrRPCInternal.Number,
+				btcjson.ErrRPCMethodNotFound.Number},
+			ids:   []interface{}{nil, float64(2)},
+			array: true,
+		},
+	}
+
+	for _, test := range tests {
+		resp, err := http.Post(server.URL, "application/json",
+			strings.NewReader(test.body))
+		if err != nil {
+			t.Errorf("%s: request failed: %v", test.name, err)
+			continue
+		}
+		body, err := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			t.Errorf("%s: failed to read reply: %v", test.name, err)
+			continue
+		}
+
+		var replies []testResponse
+		if test.array {
+			err = json.Unmarshal(body, &replies)
+		} else {
+			var reply testResponse
+			err = json.Unmarshal(body, &reply)
+			replies = append(replies, reply)
+		}
+		if err != nil {
+			t.Errorf("%s: reply %q is not the expected JSON: %v",
+				test.name, body, err)
+			continue
+		}
+		if len(replies) != len(test.codes) {
+			t.Errorf("%s: got %d replies, want %d", test.name,
+				len(replies), len(test.codes))
+			continue
+		}
+		for i, reply := range replies {
+			if reply.Error == nil {
+				t.Errorf("%s: reply %d has no error", test.name, i)
+				continue
+			}
+			if reply.Error.Code != test.codes[i] {
+				t.Errorf("%s: reply %d has error code %d, want %d",
+					test.name, i, reply.Error.Code, test.codes[i])
+			}
+			if reply.ID != test.ids[i] {
+				t.Errorf("%s: reply %d has id %v, want %v",
+					test.name, i, reply.ID, test.ids[i])
+			}
+		}
+	}
+}
@@ -126,6 +126,8 @@ type Engine struct {
 	witnessVersion  int
 	witnessProgram  []byte
 	inputAmount     int64
+	costLimit       int64
+	cost            int64
 }
 
 // hasFlag returns whether the script engine instance has the passed flag set.
@@ -133,6 +135,43 @@ func (vm *Engine) hasFlag(flag ScriptFlags) bool {
 	return vm.flags&flag == flag
 }
 
+// addCost charges n units against the evaluation cost budget of the engine
+// and returns ErrScriptCostExceeded once it has been used up.  It does nothing
+// when the engine was created without a cost limit.
+func (vm *Engine) addCost(n int) er.R {
+	if vm.costLimit <= 0 {
+		return nil
+	}
+	vm.cost += int64(n)
+	if vm.cost > vm.costLimit {
+		str := fmt.Sprintf("exceeded max evaluation cost of %d",
+			vm.costLimit)
+		return txscripterr.ScriptError(txscripterr.ErrScriptCostExceeded, str)
+	}
+	return nil
+}
+
+// addSigHashCost charges the cost of computing a signature hash over the
+// passed subscript.  Legacy signature hashes serialize a modified copy of the
+// whole transaction while witness signature hashes only cover the subscript
+// and a fixed amount of precomputed data.
+func (vm *Engine) addSigHashCost(subScript []parsescript.ParsedOpcode) er.R {
+	if vm.costLimit <= 0 {
+		return nil
+	}
+
+	// The opcode and its data are a close enough estimate of the size of
+	// the serialized subscript without having to unparse it.
+	cost := 0
+	for _, pop := range subScript {
+		cost += 1 + len(pop.Data)
+	}
+	if !vm.isWitnessVersionActive(0) {
+		cost += vm.tx.SerializeSizeStripped()
+	}
+	return vm.addCost(cost)
+}
+
 // isBranchExecuting returns whether or not the current conditional branch is
 // actively executing.  For example, when the data stack has an OP_FALSE on it
 // and an OP_IF is encountered, the branch is inactive until an OP_ELSE or
@@ -193,6 +232,10 @@ func (vm *Engine) executeOpcode(pop *parsescript.ParsedOpcode) er.R {
 		}
 	}
 
+	if err := vm.addCost(1); err != nil {
+		return err
+	}
+
 	return executeOp(pop, vm)
 }
 
@@ -980,3 +1023,25 @@ func NewEngine(scriptPubKey []byte, tx *wire.MsgTx, txIdx int, flags ScriptFlags
 
 	return &vm, nil
 }
+
+// NewEngineWithCostLimit returns a new script engine exactly like NewEngine
+// except that execution is additionally aborted with ErrScriptCostExceeded
+// once the evaluation cost exceeds costLimit.  Every executed opcode costs one
+// unit and every byte hashed, either by a hashing opcode or while computing a
+// signature hash, costs one more.
+//
+// This is a policy ceiling meant to guard relay and other non-consensus paths
+// against expensive scripts.  The consensus limits on operations and stack
+// size still apply, and a costLimit of zero or less disables the ceiling.
+func NewEngineWithCostLimit(scriptPubKey []byte, tx *wire.MsgTx, txIdx int,
+	flags ScriptFlags, sigCache *SigCache, hashCache *TxSigHashes,
+	inputAmount int64, costLimit int64) (*Engine, er.R) {
+
+	vm, err := NewEngine(scriptPubKey, tx, txIdx, flags, sigCache,
+		hashCache, inputAmount)
+	if err != nil {
+		return nil, err
+	}
+	vm.costLimit = costLimit
+	return vm, nil
+}
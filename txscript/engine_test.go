@@ -5,10 +5,14 @@
 package txscript
 
 import (
+	"strings"
 	"testing"
 
+	"github.com/pkt-cash/PKT-FullNode/btcec"
 	"github.com/pkt-cash/PKT-FullNode/chaincfg/chainhash"
 	"github.com/pkt-cash/PKT-FullNode/txscript/opcode"
+	"github.com/pkt-cash/PKT-FullNode/txscript/params"
+	"github.com/pkt-cash/PKT-FullNode/txscript/scriptbuilder"
 	"github.com/pkt-cash/PKT-FullNode/txscript/txscripterr"
 	"github.com/pkt-cash/PKT-FullNode/wire"
 )
@@ -145,6 +149,106 @@ func TestCheckErrorCondition(t *testing.T) {
 	}
 }
 
+// TestCostLimit ensures the optional evaluation cost limit aborts execution
+// once it is exceeded, that signature hashes are charged for and that it is
+// disabled when not set.
+func TestCostLimit(t *testing.T) {
+	hashTx := &wire.MsgTx{
+		Version: 1,
+		TxIn: []*wire.TxIn{{
+			PreviousOutPoint: wire.OutPoint{Index: 0},
+			SignatureScript:  mustParseShortForm("NOP"),
+			Sequence:         4294967295,
+		}},
+		TxOut: []*wire.TxOut{{
+			Value:    1000000000,
+			PkScript: nil,
+		}},
+		LockTime: 0,
+	}
+
+	// Hash 200 bytes of data twice, which costs a little over 230 units.
+	data := "'" + strings.Repeat("a", 200) + "'"
+	hashScript := mustParseShortForm(data + " SHA256 SHA256 DROP TRUE")
+
+	// Spend a pay-to-pubkey output with a valid signature.  Executing the
+	// three opcodes costs 3 units, and computing the signature hash costs
+	// the size of the subscript plus the size of the transaction.
+	key, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatalf("failed to make private key: %v", err)
+	}
+	p2pkScript, err := payToPubKeyScriptBuilder(
+		key.PubKey().SerializeCompressed()).Script()
+	if err != nil {
+		t.Fatalf("failed to make pkScript: %v", err)
+	}
+	sigTx := &wire.MsgTx{
+		Version: 1,
+		TxIn: []*wire.TxIn{{
+			PreviousOutPoint: wire.OutPoint{Index: 0},
+			Sequence:         4294967295,
+		}},
+		TxOut: []*wire.TxOut{{
+			Value:    1000000000,
+			PkScript: nil,
+		}},
+		LockTime: 0,
+	}
+	sig, err := RawTxInSignature(sigTx, 0, p2pkScript, params.SigHashAll, key)
+	if err != nil {
+		t.Fatalf("failed to sign transaction: %v", err)
+	}
+	sigTx.TxIn[0].SignatureScript, err = scriptbuilder.NewScriptBuilder().
+		AddData(sig).Script()
+	if err != nil {
+		t.Fatalf("failed to make signature script: %v", err)
+	}
+	opcodeCost := int64(3)
+	sigHashCost := int64(len(p2pkScript) + sigTx.SerializeSizeStripped())
+
+	tests := []struct {
+		name      string
+		tx        *wire.MsgTx
+		pkScript  []byte
+		costLimit int64
+		exceeded  bool
+	}{
+		{name: "disabled", tx: hashTx, pkScript: hashScript,
+			costLimit: 0, exceeded: false},
+		{name: "within limit", tx: hashTx, pkScript: hashScript,
+			costLimit: 1000, exceeded: false},
+		{name: "exceeds limit", tx: hashTx, pkScript: hashScript,
+			costLimit: 100, exceeded: true},
+		{name: "signature within limit", tx: sigTx, pkScript: p2pkScript,
+			costLimit: opcodeCost + sigHashCost, exceeded: false},
+		{name: "signature hash exceeds limit", tx: sigTx,
+			pkScript: p2pkScript, costLimit: opcodeCost + sigHashCost - 1,
+			exceeded: true},
+	}
+
+	for _, test := range tests {
+		vm, err := NewEngineWithCostLimit(test.pkScript, test.tx, 0, 0,
+			nil, nil, -1, test.costLimit)
+		if err != nil {
+			t.Fatalf("%s: failed to create script: %v", test.name, err)
+		}
+
+		err = vm.Execute()
+		if test.exceeded {
+			if !txscripterr.ErrScriptCostExceeded.Is(err) {
+				t.Errorf("%s: unexpected error: got %v, want %v",
+					test.name, err,
+					txscripterr.ErrScriptCostExceeded)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+		}
+	}
+}
+
 // TestInvalidFlagCombinations ensures the script engine returns the expected
 // error when disallowed flag combinations are specified.
 func TestInvalidFlagCombinations(t *testing.T) {
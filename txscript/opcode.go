@@ -1927,6 +1927,9 @@ func opcodeRipemd160(op *parsescript.ParsedOpcode, vm *Engine) er.R {
 	if err != nil {
 		return err
 	}
+	if err := vm.addCost(len(buf)); err != nil {
+		return err
+	}
 
 	vm.dstack.PushByteArray(btcutil.Ripemd160(buf))
 	return nil
@@ -1941,6 +1944,9 @@ func opcodeSha1(op *parsescript.ParsedOpcode, vm *Engine) er.R {
 	if err != nil {
 		return err
 	}
+	if err := vm.addCost(len(buf)); err != nil {
+		return err
+	}
 
 	hash := sha1.Sum(buf)
 	vm.dstack.PushByteArray(hash[:])
@@ -1956,6 +1962,9 @@ func opcodeSha256(op *parsescript.ParsedOpcode, vm *Engine) er.R {
 	if err != nil {
 		return err
 	}
+	if err := vm.addCost(len(buf)); err != nil {
+		return err
+	}
 
 	hash := sha256.Sum256(buf)
 	vm.dstack.PushByteArray(hash[:])
@@ -1971,6 +1980,9 @@ func opcodeHash160(op *parsescript.ParsedOpcode, vm *Engine) er.R {
 	if err != nil {
 		return err
 	}
+	if err := vm.addCost(len(buf)); err != nil {
+		return err
+	}
 
 	vm.dstack.PushByteArray(btcutil.Hash160(buf))
 	return nil
@@ -1985,6 +1997,9 @@ func opcodeHash256(op *parsescript.ParsedOpcode, vm *Engine) er.R {
 	if err != nil {
 		return err
 	}
+	if err := vm.addCost(len(buf)); err != nil {
+		return err
+	}
 
 	vm.dstack.PushByteArray(chainhash.DoubleHashB(buf))
 	return nil
@@ -2058,6 +2073,9 @@ func opcodeCheckSig(op *parsescript.ParsedOpcode, vm *Engine) er.R {
 
 	// Get script starting from the most recent OP_CODESEPARATOR.
 	subScript := vm.subScript()
+	if err := vm.addSigHashCost(subScript); err != nil {
+		return err
+	}
 
 	// Generate the signature hash based on the signature hash type.
 	var hash []byte
@@ -2332,6 +2350,10 @@ func opcodeCheckMultiSig(op *parsescript.ParsedOpcode, vm *Engine) er.R {
 			continue
 		}
 
+		if err := vm.addSigHashCost(script); err != nil {
+			return err
+		}
+
 		// Generate the signature hash based on the signature hash type.
 		var hash []byte
 		if vm.isWitnessVersionActive(0) {
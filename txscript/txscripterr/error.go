@@ -91,6 +91,10 @@ var (
 	// is over the limit.
 	ErrStackOverflow = Err.Code("ErrStackOverflow")
 
+	// ErrScriptCostExceeded is returned when the optional evaluation cost
+	// budget given to NewEngineWithCostLimit is used up.
+	ErrScriptCostExceeded = Err.Code("ErrScriptCostExceeded")
+
 	// ErrInvalidPubKeyCount is returned when the number of public keys
 	// specified for a multsig is either negative or greater than
 	// MaxPubKeysPerMultiSig.